@@ -0,0 +1,80 @@
+// Command code2epub converts a directory of source code into an EPUB3
+// book: one chapter per file plus a nav.xhtml table of contents, using the
+// same file collection and highlighting pipeline as code2pdf.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/nealhardesty/code2pdf/pkg/collect"
+	"github.com/nealhardesty/code2pdf/pkg/highlight"
+	"github.com/nealhardesty/code2pdf/pkg/render"
+)
+
+// Version is the displayed release version for --version.
+const Version = "dev"
+
+// main parses command line flags, collects files from the current
+// directory, and generates an EPUB document with processing statistics.
+func main() {
+	config := parseFlags()
+
+	files, stats, err := collect.Files(".")
+	if err != nil {
+		fmt.Printf("Error collecting files: %v\n", err)
+		os.Exit(1)
+	}
+
+	if stats.IgnoreFilesLoaded > 0 {
+		fmt.Printf("Respecting %d .gitignore/.code2pdf.ignore file(s) found while walking the tree\n", stats.IgnoreFilesLoaded)
+	} else {
+		fmt.Println("No ignore files found - processing all text files")
+	}
+
+	if len(files) == 0 {
+		fmt.Println("No files found to include in the book")
+		os.Exit(0)
+	}
+
+	if err := (render.EPUB{}).Render(files, config); err != nil {
+		fmt.Printf("Error creating EPUB: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nEPUB created successfully: %s\n", config.OutputFile)
+	fmt.Printf("Statistics: %d files included, %d files/directories ignored\n", stats.Included, stats.Ignored)
+}
+
+// parseFlags parses command line arguments and returns a render.Config
+// with the application settings.
+func parseFlags() render.Config {
+	outputFile := flag.String("o", "code.epub", "Output EPUB file name")
+	lineNumbers := flag.Bool("line-numbers", false, "Include line numbers in each chapter")
+	theme := flag.String("theme", highlight.DefaultTheme, "Syntax highlighting theme (monokai, github, solarized-light)")
+	noHighlight := flag.Bool("no-highlight", false, "Disable syntax highlighting")
+	version := flag.Bool("version", false, "Show version and exit")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "code2epub v%s - Convert code directories to an EPUB3 book\n\n", Version)
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\nOptions:\n", os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nFile filtering respects .gitignore and .code2pdf.ignore files.\n")
+	}
+
+	flag.Parse()
+
+	if *version {
+		fmt.Printf("code2epub %s\n", Version)
+		os.Exit(0)
+	}
+
+	return render.Config{
+		OutputFile:  *outputFile,
+		LineNumbers: *lineNumbers,
+		Theme:       *theme,
+		NoHighlight: *noHighlight,
+		WrapMode:    render.DefaultWrapMode,
+	}
+}
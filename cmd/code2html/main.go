@@ -0,0 +1,85 @@
+// Command code2html converts a directory of source code into a single
+// self-contained HTML document: a table of contents and each file's
+// content with syntax highlighting, using the same file collection and
+// highlighting pipeline as code2pdf.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/nealhardesty/code2pdf/pkg/collect"
+	"github.com/nealhardesty/code2pdf/pkg/highlight"
+	"github.com/nealhardesty/code2pdf/pkg/render"
+)
+
+// Version is the displayed release version for --version.
+const Version = "dev"
+
+// main parses command line flags, collects files from the current
+// directory, and generates an HTML document with processing statistics.
+func main() {
+	config := parseFlags()
+
+	files, stats, err := collect.Files(".")
+	if err != nil {
+		fmt.Printf("Error collecting files: %v\n", err)
+		os.Exit(1)
+	}
+
+	if stats.IgnoreFilesLoaded > 0 {
+		fmt.Printf("Respecting %d .gitignore/.code2pdf.ignore file(s) found while walking the tree\n", stats.IgnoreFilesLoaded)
+	} else {
+		fmt.Println("No ignore files found - processing all text files")
+	}
+
+	if len(files) == 0 {
+		fmt.Println("No files found to include in the document")
+		os.Exit(0)
+	}
+
+	if err := (render.HTML{}).Render(files, config); err != nil {
+		fmt.Printf("Error creating HTML: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nHTML created successfully: %s\n", config.OutputFile)
+	fmt.Printf("Statistics: %d files included, %d files/directories ignored\n", stats.Included, stats.Ignored)
+}
+
+// parseFlags parses command line arguments and returns a render.Config
+// with the application settings.
+func parseFlags() render.Config {
+	outputFile := flag.String("o", "code.html", "Output HTML file name")
+	fontSize := flag.Float64("font-size", 10.0, "Font size for code, in points")
+	fontName := flag.String("font", "Courier", "Font name used for the code body (Courier, Helvetica, Times)")
+	lineNumbers := flag.Bool("line-numbers", false, "Include line numbers in the document")
+	theme := flag.String("theme", highlight.DefaultTheme, "Syntax highlighting theme (monokai, github, solarized-light)")
+	noHighlight := flag.Bool("no-highlight", false, "Disable syntax highlighting")
+	version := flag.Bool("version", false, "Show version and exit")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "code2html v%s - Convert code directories to a single HTML document\n\n", Version)
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\nOptions:\n", os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nFile filtering respects .gitignore and .code2pdf.ignore files.\n")
+	}
+
+	flag.Parse()
+
+	if *version {
+		fmt.Printf("code2html %s\n", Version)
+		os.Exit(0)
+	}
+
+	return render.Config{
+		OutputFile:  *outputFile,
+		FontSize:    *fontSize,
+		FontName:    *fontName,
+		LineNumbers: *lineNumbers,
+		Theme:       *theme,
+		NoHighlight: *noHighlight,
+		WrapMode:    render.DefaultWrapMode,
+	}
+}
@@ -0,0 +1,150 @@
+// Command code2pdf converts a directory of source code into a single PDF
+// document: a title page, a clickable table of contents, and each file's
+// content with syntax highlighting, line numbers, and bookmarks.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nealhardesty/code2pdf/pkg/collect"
+	"github.com/nealhardesty/code2pdf/pkg/highlight"
+	"github.com/nealhardesty/code2pdf/pkg/render"
+)
+
+// Version is the displayed release version for --version.
+const Version = "dev"
+
+// main is the entry point of the application. It parses command line flags,
+// collects files from the current directory (honoring every .gitignore and
+// .code2pdf.ignore found while walking it) with detailed logging, and
+// generates a PDF document with comprehensive processing statistics.
+func main() {
+	config := parseFlags()
+
+	files, stats, err := collect.Files(".")
+	if err != nil {
+		fmt.Printf("Error collecting files: %v\n", err)
+		os.Exit(1)
+	}
+
+	if stats.IgnoreFilesLoaded > 0 {
+		fmt.Printf("Respecting %d .gitignore/.code2pdf.ignore file(s) found while walking the tree\n", stats.IgnoreFilesLoaded)
+	} else {
+		fmt.Println("No ignore files found - processing all text files")
+	}
+
+	if len(files) == 0 {
+		fmt.Println("No files found to include in the PDF")
+		os.Exit(0)
+	}
+
+	if err := (render.PDF{}).Render(files, config); err != nil {
+		fmt.Printf("Error creating PDF: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nPDF created successfully: %s\n", config.OutputFile)
+	printStats(stats)
+}
+
+// printStats reports processing totals and the most common file
+// extensions included, shared in spirit with code2html/code2epub's own
+// summaries.
+func printStats(stats collect.Stats) {
+	fmt.Printf("Statistics: %d files included, %d files/directories ignored\n", stats.Included, stats.Ignored)
+
+	if len(stats.Extensions) == 0 {
+		return
+	}
+	fmt.Printf("Top file types included:\n")
+
+	type extCount struct {
+		ext   string
+		count int
+	}
+	var extCounts []extCount
+	for ext, count := range stats.Extensions {
+		extCounts = append(extCounts, extCount{ext, count})
+	}
+
+	// Break count ties by extension name so the top-5 listing doesn't
+	// depend on Go's unspecified map iteration order.
+	sort.Slice(extCounts, func(i, j int) bool {
+		if extCounts[i].count != extCounts[j].count {
+			return extCounts[i].count > extCounts[j].count
+		}
+		return extCounts[i].ext < extCounts[j].ext
+	})
+
+	limit := len(extCounts)
+	if limit > 5 {
+		limit = 5
+	}
+	for i := 0; i < limit; i++ {
+		fmt.Printf("  %s: %d files\n", extCounts[i].ext, extCounts[i].count)
+	}
+}
+
+// parseFlags parses command line arguments and returns a render.Config
+// with the application settings.
+func parseFlags() render.Config {
+	outputFile := flag.String("o", "code.pdf", "Output PDF file name")
+	fontSize := flag.Float64("font-size", 7.0, "Font size for code")
+	fontName := flag.String("font", "Courier", "Font name (Courier, Helvetica, Times)")
+	lineNumbers := flag.Bool("line-numbers", false, "Include line numbers in the PDF")
+	landscape := flag.Bool("landscape", true, "Use landscape orientation instead of portrait")
+	theme := flag.String("theme", highlight.DefaultTheme, "Syntax highlighting theme (monokai, github, solarized-light)")
+	noHighlight := flag.Bool("no-highlight", false, "Disable syntax highlighting")
+	ttfFont := flag.String("ttf-font", "", "Path to a UTF-8 TrueType font for the code body (default: embedded DejaVu Sans Mono)")
+	fontFallback := flag.String("font-fallback", "", "Comma-separated TTF paths for scripts outside the primary font (1st: CJK, 2nd: emoji/symbols)")
+	wrapMode := flag.String("wrap-mode", render.DefaultWrapMode, "How to handle lines wider than the page: truncate, wrap, overflow")
+	creationDate := flag.String("creation-date", "", "Override the PDF's embedded creation timestamp (RFC3339, e.g. 2000-01-01T00:00:00Z); defaults to SOURCE_DATE_EPOCH if set, then the current time")
+	version := flag.Bool("version", false, "Show version and exit")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "code2pdf v%s - Convert code directories to PDF documents\n\n", Version)
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\nOptions:\n", os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nFile filtering respects .gitignore and .code2pdf.ignore files.\n")
+	}
+
+	flag.Parse()
+
+	if *version {
+		fmt.Printf("code2pdf %s\n", Version)
+		os.Exit(0)
+	}
+
+	var fallbacks []string
+	if *fontFallback != "" {
+		fallbacks = strings.Split(*fontFallback, ",")
+	}
+
+	if !render.WrapModes[*wrapMode] {
+		*wrapMode = render.DefaultWrapMode
+	}
+
+	when, err := render.ReproducibleCreationDate(*creationDate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "code2pdf: %v\n", err)
+		os.Exit(1)
+	}
+
+	return render.Config{
+		OutputFile:   *outputFile,
+		FontSize:     *fontSize,
+		FontName:     *fontName,
+		LineNumbers:  *lineNumbers,
+		Landscape:    *landscape,
+		Theme:        *theme,
+		NoHighlight:  *noHighlight,
+		TTFFont:      *ttfFont,
+		FontFallback: fallbacks,
+		WrapMode:     *wrapMode,
+		CreationDate: when,
+	}
+}
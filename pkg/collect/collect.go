@@ -0,0 +1,404 @@
+// Package collect walks a directory tree and gathers metadata for every
+// text file in it, honoring .gitignore/.code2pdf.ignore files cascaded
+// per-directory. It's shared by every code2pdf-family command: the walk and
+// per-file sniffing happen once, and the resulting []FileEntry is handed to
+// whichever render.Renderer the command wants (PDF, HTML, EPUB).
+//
+// Files returns only after every candidate has been sniffed: the worker
+// pool in processCandidates parallelizes the text/language detection
+// itself, but there's no channel handoff into the renderer, so collection
+// and rendering remain two sequential stages from the caller's point of
+// view rather than an overlapping pipeline. Renderers need the complete,
+// sorted []FileEntry up front anyway - PDF's table of contents and
+// per-file bookmarks (see render.PDF) are built from the full list before
+// the first file page is written - so overlapping collection with
+// rendering would need those renderers restructured too, not just Files.
+package collect
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nealhardesty/code2pdf/pkg/highlight"
+	"github.com/nealhardesty/code2pdf/pkg/ignore"
+)
+
+// FileEntry represents a file to be included in the output document. It
+// carries only metadata, not content: renderers stream each file's body
+// from disk as they render it, rather than holding every file's full
+// content in memory at once.
+type FileEntry struct {
+	Path     string
+	Size     int64
+	ModTime  string
+	Language string // Detected language, e.g. "go", "python"
+}
+
+// Stats holds statistics about file processing.
+type Stats struct {
+	Included          int
+	Ignored           int
+	Extensions        map[string]int
+	IgnoreFilesLoaded int
+}
+
+// candidateFile is a path that survived ignore-pattern filtering during the
+// (sequential) walk and is waiting to be sniffed for text/language by the
+// worker pool in processCandidates.
+type candidateFile struct {
+	path    string
+	size    int64
+	modTime string
+}
+
+// ignoreFilenames are the per-directory pattern files Files looks for and
+// loads into the ignore.Matcher as it walks into each directory.
+var ignoreFilenames = []string{".gitignore", ".code2pdf.ignore"}
+
+// Files walks the directory tree starting from root, honoring every
+// .gitignore/.code2pdf.ignore found along the way (each scoped to its own
+// subtree), and collects metadata for all text files found. The walk
+// itself stays sequential, since nested ignore files must be loaded in
+// directory order, but the per-file text/language sniffing - the part that
+// actually touches file content - is handed off to a worker pool in
+// processCandidates, parallelizing that one CPU/IO-bound phase. Files
+// still blocks until every candidate is sniffed before returning; it does
+// not stream results out for the caller to overlap with rendering. Returns
+// a slice of FileEntry structs, sorted by path, plus detailed processing
+// statistics including file counts and extension breakdowns.
+func Files(root string) ([]FileEntry, Stats, error) {
+	var candidates []candidateFile
+	stats := Stats{
+		Extensions: make(map[string]int),
+	}
+	matcher := ignore.New()
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			loadIgnoreFilesForDir(matcher, path, &stats)
+		}
+
+		// The walk root itself is never checked against its own rules.
+		if path != root {
+			if ignored, rule, source := matcher.Match(path, info.IsDir()); ignored {
+				if info.IsDir() {
+					fmt.Printf("Ignoring directory %s [%s: %s]\n", path, source, rule)
+					stats.Ignored++
+					return filepath.SkipDir
+				}
+				fmt.Printf("Ignoring %s [%s: %s]\n", path, source, rule)
+				stats.Ignored++
+				return nil
+			}
+		}
+
+		// Don't process directories themselves
+		if info.IsDir() {
+			return nil
+		}
+
+		candidates = append(candidates, candidateFile{
+			path:    path,
+			size:    info.Size(),
+			modTime: info.ModTime().Format("2006-01-02 15:04:05"),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, stats, err
+	}
+
+	// filepath.Walk already visits each directory's children in sorted
+	// order, but sort the final slice explicitly too so output doesn't
+	// depend on the worker pool's completion order under the hood.
+	files := processCandidates(candidates, &stats)
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, stats, nil
+}
+
+// readLines returns the lines of filename, or nil if it doesn't exist or
+// can't be read. Blank-line/comment filtering is left to the ignore
+// package, which needs the raw lines to apply gitignore syntax.
+func readLines(filename string) []string {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+// loadIgnoreFilesForDir reads any .gitignore/.code2pdf.ignore present in
+// dir and adds their patterns to matcher, scoped to dir's subtree.
+func loadIgnoreFilesForDir(matcher *ignore.Matcher, dir string, stats *Stats) {
+	for _, name := range ignoreFilenames {
+		lines := readLines(filepath.Join(dir, name))
+		if lines == nil {
+			continue
+		}
+		matcher.LoadDir(dir, lines, name)
+		stats.IgnoreFilesLoaded++
+	}
+}
+
+// candidateResult is what a worker reports back for one candidateFile: the
+// original index (so results can be reassembled in the walk's original
+// order despite finishing out of order) plus whether it turned out to be a
+// text file worth including.
+type candidateResult struct {
+	index    int
+	path     string
+	included bool
+	entry    FileEntry
+}
+
+// processCandidates sniffs each candidate's content to decide whether it's
+// a text file (and, if so, its language) using a bounded pool of
+// runtime.NumCPU() workers, since that's the part of Files that actually
+// has to touch disk and CPU per file. Results are collected back into the
+// candidates' original (deterministic, lexically-walked) order before
+// being returned, so output doesn't depend on goroutine scheduling.
+func processCandidates(candidates []candidateFile, stats *Stats) []FileEntry {
+	jobs := make(chan int)
+	results := make(chan candidateResult, len(candidates))
+
+	workers := runtime.NumCPU()
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(candidates) {
+		workers = len(candidates)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results <- sniffCandidate(i, candidates[i])
+			}
+		}()
+	}
+
+	go func() {
+		for i := range candidates {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	ordered := make([]candidateResult, len(candidates))
+	for res := range results {
+		ordered[res.index] = res
+	}
+
+	files := make([]FileEntry, 0, len(candidates))
+	for _, res := range ordered {
+		if !res.included {
+			fmt.Printf("Skipping binary file: %s\n", res.path)
+			stats.Ignored++
+			continue
+		}
+		files = append(files, res.entry)
+		stats.Included++
+		ext := strings.ToLower(filepath.Ext(res.path))
+		if ext == "" {
+			ext = "(no extension)"
+		}
+		stats.Extensions[ext]++
+	}
+	return files
+}
+
+// sniffCandidate is the per-file work a processCandidates worker runs: read
+// enough of the file to classify it as text/binary and, if text, detect
+// its language.
+func sniffCandidate(index int, c candidateFile) candidateResult {
+	isText, language := detectTextAndLanguage(c.path)
+	if !isText {
+		return candidateResult{index: index, path: c.path, included: false}
+	}
+	return candidateResult{
+		index:    index,
+		path:     c.path,
+		included: true,
+		entry: FileEntry{
+			Path:     c.path,
+			Size:     c.size,
+			ModTime:  c.modTime,
+			Language: language,
+		},
+	}
+}
+
+// looksLikeText applies heuristics to a sniffed chunk of a file's content -
+// null byte detection, UTF-8 validation, and printable character ratio
+// analysis - to decide whether the file is text.
+func looksLikeText(data []byte) bool {
+	n := len(data)
+	if n == 0 {
+		return true
+	}
+
+	// Check for null bytes (strong indicator of binary content)
+	nullCount := 0
+	for i := 0; i < n; i++ {
+		if data[i] == 0 {
+			nullCount++
+		}
+	}
+
+	// If more than 1% null bytes, likely binary
+	if float64(nullCount)/float64(n) > 0.01 {
+		return false
+	}
+
+	// Check if content is valid UTF-8
+	if !isValidUTF8(data) {
+		return false
+	}
+
+	// Count printable characters
+	printableCount := 0
+	for _, b := range data {
+		if isPrintableASCII(b) || b == '\t' || b == '\n' || b == '\r' {
+			printableCount++
+		}
+	}
+
+	// If less than 70% printable characters, likely binary
+	printableRatio := float64(printableCount) / float64(n)
+	return printableRatio >= 0.70
+}
+
+// detectTextAndLanguage sniffs up to 8KB of path and reports whether it
+// looks like a text file plus, if so, its detected language. It reads only
+// the sniffed chunk rather than the whole file, since document bodies are
+// streamed from disk separately at render time.
+func detectTextAndLanguage(path string) (isText bool, language string) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, ""
+	}
+	defer file.Close()
+
+	buf := make([]byte, 8192)
+	n, err := file.Read(buf)
+	if err != nil && n == 0 {
+		return false, ""
+	}
+	data := buf[:n]
+
+	if !looksLikeText(data) {
+		return false, ""
+	}
+	return true, highlight.DetectLanguage(path, data)
+}
+
+// isValidUTF8 checks if the data is valid UTF-8
+func isValidUTF8(data []byte) bool {
+	for len(data) > 0 {
+		r, size := decodeUTF8Rune(data)
+		if r == 0xFFFD && size == 1 {
+			return false // Invalid UTF-8 sequence
+		}
+		data = data[size:]
+	}
+	return true
+}
+
+// decodeUTF8Rune decodes a single UTF-8 rune from data
+func decodeUTF8Rune(data []byte) (rune, int) {
+	if len(data) == 0 {
+		return 0, 0
+	}
+
+	b0 := data[0]
+
+	// ASCII
+	if b0 < 0x80 {
+		return rune(b0), 1
+	}
+
+	// Multi-byte sequences
+	if b0 < 0xC2 {
+		return 0xFFFD, 1
+	}
+
+	if b0 < 0xE0 {
+		if len(data) < 2 {
+			return 0xFFFD, 1
+		}
+		b1 := data[1]
+		if b1 < 0x80 || b1 >= 0xC0 {
+			return 0xFFFD, 1
+		}
+		return rune(b0&0x1F)<<6 | rune(b1&0x3F), 2
+	}
+
+	if b0 < 0xF0 {
+		if len(data) < 3 {
+			return 0xFFFD, 1
+		}
+		b1, b2 := data[1], data[2]
+		if b1 < 0x80 || b1 >= 0xC0 || b2 < 0x80 || b2 >= 0xC0 {
+			return 0xFFFD, 1
+		}
+		return rune(b0&0x0F)<<12 | rune(b1&0x3F)<<6 | rune(b2&0x3F), 3
+	}
+
+	if b0 < 0xF8 {
+		if len(data) < 4 {
+			return 0xFFFD, 1
+		}
+		b1, b2, b3 := data[1], data[2], data[3]
+		if b1 < 0x80 || b1 >= 0xC0 || b2 < 0x80 || b2 >= 0xC0 || b3 < 0x80 || b3 >= 0xC0 {
+			return 0xFFFD, 1
+		}
+		return rune(b0&0x07)<<18 | rune(b1&0x3F)<<12 | rune(b2&0x3F)<<6 | rune(b3&0x3F), 4
+	}
+
+	return 0xFFFD, 1
+}
+
+// isPrintableASCII checks if a byte is a printable ASCII character
+func isPrintableASCII(b byte) bool {
+	return b >= 32 && b <= 126
+}
+
+// FormatFileSize converts a file size in bytes to a human-readable format
+// using appropriate units (B, KB, MB, GB, etc.).
+func FormatFileSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.2f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,29 @@
+// Package compare byte-compares rendered documents against checked-in
+// reference files, for use in golden-file tests where the renderer's
+// output should otherwise be fully deterministic.
+package compare
+
+import "regexp"
+
+// creationDatePattern matches a PDF's /CreationDate entry, e.g.
+// "/CreationDate (D:20060102150405Z)", so it can be normalized away before
+// comparing two PDFs that were rendered at different times.
+var creationDatePattern = regexp.MustCompile(`/CreationDate\s*\(D:[0-9]{14}[^)]*\)`)
+
+// fixedCreationDate is substituted for the real timestamp before
+// comparison; its value doesn't matter since both sides are normalized to
+// the same constant.
+const fixedCreationDate = "/CreationDate (D:20000101000000)"
+
+// NormalizePDF rewrites data's /CreationDate entry, if any, to a fixed
+// value, so two PDFs rendered at different times can be compared for
+// equality despite that one field differing.
+func NormalizePDF(data []byte) []byte {
+	return creationDatePattern.ReplaceAll(data, []byte(fixedCreationDate))
+}
+
+// EqualPDF reports whether got and want are byte-identical after
+// NormalizePDF has been applied to both.
+func EqualPDF(got, want []byte) bool {
+	return string(NormalizePDF(got)) == string(NormalizePDF(want))
+}
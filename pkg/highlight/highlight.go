@@ -0,0 +1,309 @@
+// Package highlight provides lightweight syntax highlighting for source code.
+// It detects a file's language from its extension (falling back to content
+// sniffing for extensionless files such as Dockerfile or Makefile), tokenizes
+// each line into runs of (text, class) pairs, and maps those classes to RGB
+// colors via a selectable theme.
+package highlight
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TokenClass identifies the syntactic role of a run of text.
+type TokenClass int
+
+// The token classes recognized by the tokenizer. TokenPlain is the
+// zero value and is used for text that doesn't match any other class.
+const (
+	TokenPlain TokenClass = iota
+	TokenKeyword
+	TokenString
+	TokenComment
+	TokenNumber
+	TokenIdentifier
+	TokenOperator
+)
+
+// Token is a single colored run produced by Tokenize.
+type Token struct {
+	Text  string
+	Class TokenClass
+}
+
+// RGB is a simple 0-255 color triple, matching the signature expected by
+// gofpdf's SetTextColor.
+type RGB struct {
+	R, G, B int
+}
+
+// Theme maps each token class to the color it should be rendered in.
+type Theme map[TokenClass]RGB
+
+// themes holds the built-in themes selectable via the --theme flag.
+var themes = map[string]Theme{
+	"monokai": {
+		TokenPlain:      {248, 248, 242},
+		TokenKeyword:    {249, 38, 114},
+		TokenString:     {230, 219, 116},
+		TokenComment:    {117, 113, 94},
+		TokenNumber:     {174, 129, 255},
+		TokenIdentifier: {166, 226, 46},
+		TokenOperator:   {248, 248, 242},
+	},
+	"github": {
+		TokenPlain:      {36, 41, 46},
+		TokenKeyword:    {215, 58, 73},
+		TokenString:     {3, 47, 98},
+		TokenComment:    {106, 115, 125},
+		TokenNumber:     {0, 92, 197},
+		TokenIdentifier: {111, 66, 193},
+		TokenOperator:   {36, 41, 46},
+	},
+	"solarized-light": {
+		TokenPlain:      {101, 123, 131},
+		TokenKeyword:    {133, 153, 0},
+		TokenString:     {42, 161, 152},
+		TokenComment:    {147, 161, 161},
+		TokenNumber:     {211, 54, 130},
+		TokenIdentifier: {38, 139, 210},
+		TokenOperator:   {101, 123, 131},
+	},
+}
+
+// DefaultTheme is used when an unrecognized --theme value is supplied.
+const DefaultTheme = "monokai"
+
+// Themes returns the names of all built-in themes.
+func Themes() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	return names
+}
+
+// GetTheme looks up a theme by name (case-insensitive). It returns false if
+// the theme is not known, in which case callers should fall back to
+// DefaultTheme.
+func GetTheme(name string) (Theme, bool) {
+	theme, ok := themes[strings.ToLower(name)]
+	return theme, ok
+}
+
+// extensionLanguages maps lowercase file extensions (including the leading
+// dot) to a language name.
+var extensionLanguages = map[string]string{
+	".go":    "go",
+	".py":    "python",
+	".js":    "javascript",
+	".jsx":   "javascript",
+	".ts":    "typescript",
+	".tsx":   "typescript",
+	".java":  "java",
+	".c":     "c",
+	".h":     "c",
+	".cpp":   "cpp",
+	".cc":    "cpp",
+	".hpp":   "cpp",
+	".rs":    "rust",
+	".rb":    "ruby",
+	".sh":    "shell",
+	".bash":  "shell",
+	".yml":   "yaml",
+	".yaml":  "yaml",
+	".json":  "json",
+	".xml":   "markup",
+	".html":  "markup",
+	".md":    "markdown",
+	".sql":   "sql",
+}
+
+// basenameLanguages maps the exact basename of extensionless files to a
+// language name.
+var basenameLanguages = map[string]string{
+	"Dockerfile": "dockerfile",
+	"Makefile":   "makefile",
+}
+
+// shebangLanguages maps an interpreter name found on a "#!" line to a
+// language name, used as a last resort for extensionless scripts.
+var shebangLanguages = map[string]string{
+	"python":  "python",
+	"python3": "python",
+	"bash":    "shell",
+	"sh":      "shell",
+	"node":    "javascript",
+	"ruby":    "ruby",
+}
+
+// DetectLanguage returns the best-guess language for a file, given its path
+// and (optionally empty) leading content. Detection proceeds: extension,
+// then exact basename, then a "#!" shebang line, then "plaintext".
+func DetectLanguage(path string, content []byte) string {
+	ext := strings.ToLower(extOf(path))
+	if lang, ok := extensionLanguages[ext]; ok {
+		return lang
+	}
+
+	base := baseOf(path)
+	if lang, ok := basenameLanguages[base]; ok {
+		return lang
+	}
+
+	if lang, ok := detectShebang(content); ok {
+		return lang
+	}
+
+	return "plaintext"
+}
+
+func extOf(path string) string {
+	i := strings.LastIndexByte(path, '.')
+	slash := strings.LastIndexAny(path, "/\\")
+	if i <= slash {
+		return ""
+	}
+	return path[i:]
+}
+
+func baseOf(path string) string {
+	i := strings.LastIndexAny(path, "/\\")
+	return path[i+1:]
+}
+
+func detectShebang(content []byte) (string, bool) {
+	firstLine := content
+	if i := strings.IndexByte(string(content), '\n'); i >= 0 {
+		firstLine = content[:i]
+	}
+	line := strings.TrimSpace(string(firstLine))
+	if !strings.HasPrefix(line, "#!") {
+		return "", false
+	}
+	interp := line[strings.LastIndexByte(line, '/')+1:]
+	fields := strings.Fields(interp)
+	if len(fields) == 0 {
+		return "", false
+	}
+	lang, ok := shebangLanguages[fields[0]]
+	return lang, ok
+}
+
+// keywordsByLanguage lists the reserved words highlighted as TokenKeyword
+// for each language Tokenize understands. Languages not listed here still
+// get string/comment/number/operator highlighting, just no keywords.
+var keywordsByLanguage = map[string][]string{
+	"go": {"func", "package", "import", "var", "const", "type", "struct",
+		"interface", "return", "if", "else", "for", "range", "switch",
+		"case", "default", "break", "continue", "go", "defer", "chan",
+		"select", "map", "nil", "true", "false", "fallthrough", "goto"},
+	"python": {"def", "class", "import", "from", "return", "if", "elif",
+		"else", "for", "while", "try", "except", "finally", "with", "as",
+		"lambda", "None", "True", "False", "pass", "break", "continue",
+		"yield", "global", "nonlocal", "raise"},
+	"javascript": {"function", "return", "if", "else", "for", "while", "var",
+		"let", "const", "class", "extends", "new", "this", "typeof",
+		"instanceof", "null", "undefined", "true", "false", "try", "catch",
+		"finally", "throw", "switch", "case", "default", "break",
+		"continue", "import", "export", "from", "async", "await"},
+	"typescript": {"function", "return", "if", "else", "for", "while", "var",
+		"let", "const", "class", "extends", "new", "this", "typeof",
+		"instanceof", "null", "undefined", "true", "false", "try", "catch",
+		"finally", "throw", "switch", "case", "default", "break",
+		"continue", "import", "export", "from", "async", "await",
+		"interface", "type", "implements", "enum", "namespace"},
+	"java": {"class", "interface", "extends", "implements", "public",
+		"private", "protected", "static", "final", "void", "return", "if",
+		"else", "for", "while", "new", "this", "super", "try", "catch",
+		"finally", "throw", "throws", "import", "package", "null", "true",
+		"false"},
+	"c": {"int", "char", "float", "double", "void", "struct", "union",
+		"enum", "typedef", "return", "if", "else", "for", "while", "do",
+		"switch", "case", "default", "break", "continue", "static",
+		"const", "sizeof", "include", "define"},
+	"cpp": {"int", "char", "float", "double", "void", "class", "struct",
+		"namespace", "template", "typename", "public", "private",
+		"protected", "return", "if", "else", "for", "while", "do", "new",
+		"delete", "this", "try", "catch", "throw", "const", "static",
+		"virtual", "override", "nullptr", "true", "false"},
+	"rust": {"fn", "let", "mut", "struct", "enum", "impl", "trait", "pub",
+		"return", "if", "else", "for", "while", "loop", "match", "use",
+		"mod", "crate", "self", "Self", "true", "false", "None", "Some"},
+	"ruby": {"def", "class", "module", "end", "return", "if", "elsif",
+		"else", "unless", "while", "until", "do", "begin", "rescue",
+		"ensure", "yield", "nil", "true", "false", "require", "attr_accessor"},
+	"shell": {"if", "then", "else", "elif", "fi", "for", "while", "do",
+		"done", "case", "esac", "function", "return", "local", "export"},
+}
+
+// stringPattern matches single- and double-quoted string literals
+// (backslash-escaped quotes are honored). commentPattern matches
+// line comments in the common "//", "#" styles. numberPattern matches
+// integer and floating point literals. identifierPattern matches bare
+// words, used both for keyword lookup and as TokenIdentifier.
+var (
+	stringPattern     = regexp.MustCompile(`^(?:"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*')`)
+	commentPattern    = regexp.MustCompile(`^(?://.*|#.*)`)
+	numberPattern     = regexp.MustCompile(`^[0-9]+(?:\.[0-9]+)?`)
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+	operatorPattern   = regexp.MustCompile(`^[{}()\[\]<>=+\-*/%!&|^~.,;:]+`)
+)
+
+// Tokenize splits a single line of source code into colored runs. It is
+// intentionally line-oriented (rather than a full lexer) since createPDF
+// renders one line at a time; multi-line constructs like block comments or
+// triple-quoted strings are not tracked across lines.
+func Tokenize(language, line string) []Token {
+	keywords := keywordSet(language)
+
+	var tokens []Token
+	for len(line) > 0 {
+		switch {
+		case commentPattern.MatchString(line):
+			m := commentPattern.FindString(line)
+			tokens = append(tokens, Token{Text: m, Class: TokenComment})
+			line = line[len(m):]
+			continue
+		case stringPattern.MatchString(line):
+			m := stringPattern.FindString(line)
+			tokens = append(tokens, Token{Text: m, Class: TokenString})
+			line = line[len(m):]
+			continue
+		case numberPattern.MatchString(line):
+			m := numberPattern.FindString(line)
+			tokens = append(tokens, Token{Text: m, Class: TokenNumber})
+			line = line[len(m):]
+			continue
+		case identifierPattern.MatchString(line):
+			m := identifierPattern.FindString(line)
+			class := TokenIdentifier
+			if keywords[m] {
+				class = TokenKeyword
+			}
+			tokens = append(tokens, Token{Text: m, Class: class})
+			line = line[len(m):]
+			continue
+		case operatorPattern.MatchString(line):
+			m := operatorPattern.FindString(line)
+			tokens = append(tokens, Token{Text: m, Class: TokenOperator})
+			line = line[len(m):]
+			continue
+		default:
+			// Whitespace or an unrecognized rune: consume one rune as
+			// plain text so we always make progress.
+			r := []rune(line)[0]
+			tokens = append(tokens, Token{Text: string(r), Class: TokenPlain})
+			line = line[len(string(r)):]
+		}
+	}
+	return tokens
+}
+
+func keywordSet(language string) map[string]bool {
+	set := make(map[string]bool)
+	for _, kw := range keywordsByLanguage[language] {
+		set[kw] = true
+	}
+	return set
+}
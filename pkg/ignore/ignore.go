@@ -0,0 +1,235 @@
+// Package ignore implements gitignore-compatible path matching: anchored
+// patterns, "**", character classes, negation (with last-match-wins
+// ordering), and per-directory pattern files that only apply to their own
+// subtree. It's built to be fed incrementally as a directory tree is
+// walked, since nested ".gitignore" files are only known once their
+// directory is reached.
+package ignore
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// pattern is a single compiled line from a pattern file.
+type pattern struct {
+	raw      string // original text, for reporting which rule matched
+	source   string // filename the pattern came from, e.g. ".gitignore"
+	baseDir  string // slash-separated dir (relative to the walk root) the pattern file lives in; "" for the root
+	negate   bool   // leading "!"
+	dirOnly  bool   // trailing "/"
+	anchored bool   // pattern is relative to baseDir rather than matching at any depth
+	re       *regexp.Regexp
+}
+
+// Matcher accumulates pattern files discovered while walking a directory
+// tree and answers whether a given path is ignored.
+type Matcher struct {
+	patterns []pattern
+}
+
+// New returns an empty Matcher. Call LoadDir for the walk root and for each
+// directory as it's visited.
+func New() *Matcher {
+	return &Matcher{}
+}
+
+// LoadDir reads each of the given pattern filenames (e.g. ".gitignore",
+// ".code2pdf.ignore") from dir if present, and adds their patterns scoped
+// to dir's subtree. dir is the directory's path relative to the walk root
+// ("." or "" for the root itself), using either slash style.
+//
+// Patterns are appended in the order loaded, which callers should preserve
+// as root-first, since Match treats later-loaded patterns as taking
+// precedence when there's a conflict, matching git's own last-match-wins
+// behavior across cascaded .gitignore files.
+func (m *Matcher) LoadDir(dir string, lines []string, source string) {
+	baseDir := normalizeDir(dir)
+	for _, line := range lines {
+		p, ok := compilePattern(line, baseDir, source)
+		if ok {
+			m.patterns = append(m.patterns, p)
+		}
+	}
+}
+
+// Match reports whether path (relative to the walk root, "/"- or
+// OS-separator-delimited) is ignored, along with the rule and source file
+// responsible so callers can log a helpful message. isDir must reflect
+// whether path is a directory, since some patterns only apply to
+// directories.
+func (m *Matcher) Match(filePath string, isDir bool) (ignored bool, rule, source string) {
+	clean := normalizeDir(filePath)
+
+	if clean == ".git" || strings.HasPrefix(clean, ".git/") {
+		return true, ".git/", "(default)"
+	}
+
+	for _, p := range m.patterns {
+		rel, ok := relativeTo(clean, p.baseDir)
+		if !ok || rel == "." {
+			continue
+		}
+		if p.dirOnly && !isDir {
+			continue
+		}
+		var matched bool
+		if p.anchored {
+			matched = p.re.MatchString(rel)
+		} else {
+			matched = p.re.MatchString(path.Base(rel))
+		}
+		if matched {
+			ignored = !p.negate
+			rule = p.raw
+			source = p.source
+		}
+	}
+	return ignored, rule, source
+}
+
+// normalizeDir converts an OS path to the slash-separated, "./"-stripped
+// form Matcher compares internally.
+func normalizeDir(p string) string {
+	p = filepath.ToSlash(p)
+	p = strings.TrimPrefix(p, "./")
+	p = strings.TrimSuffix(p, "/")
+	if p == "" {
+		p = "."
+	}
+	return p
+}
+
+// relativeTo returns filePath relative to baseDir (both slash-separated,
+// normalized), or ok=false if filePath doesn't fall under baseDir.
+func relativeTo(filePath, baseDir string) (string, bool) {
+	if baseDir == "." || baseDir == "" {
+		return filePath, true
+	}
+	if filePath == baseDir {
+		return ".", true
+	}
+	if strings.HasPrefix(filePath, baseDir+"/") {
+		return filePath[len(baseDir)+1:], true
+	}
+	return "", false
+}
+
+// compilePattern parses a single gitignore-syntax line into a pattern
+// scoped to baseDir. It returns ok=false for blank lines and comments.
+func compilePattern(line, baseDir, source string) (pattern, bool) {
+	raw := line
+	line = strings.TrimRight(line, " ")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return pattern{}, false
+	}
+
+	negate := false
+	if strings.HasPrefix(line, "!") {
+		negate = true
+		line = line[1:]
+	}
+
+	dirOnly := false
+	if strings.HasSuffix(line, "/") {
+		dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if line == "" {
+		return pattern{}, false
+	}
+
+	anchored := strings.HasPrefix(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if strings.Contains(line, "/") {
+		// A slash anywhere but the very end anchors the pattern to
+		// baseDir, per the gitignore spec.
+		anchored = true
+	}
+
+	re, err := regexp.Compile("^" + globToRegex(line) + "$")
+	if err != nil {
+		return pattern{}, false
+	}
+
+	return pattern{
+		raw:      raw,
+		source:   source,
+		baseDir:  baseDir,
+		negate:   negate,
+		dirOnly:  dirOnly,
+		anchored: anchored,
+		re:       re,
+	}, true
+}
+
+// Sentinel bytes used to carry the three special "**" forms through the
+// rune-by-rune escaping pass below without them being mistaken for a
+// literal "*" pair. \x00 can't appear in a pattern read from a text file.
+const (
+	tokenMidDoubleStar   = "\x00A\x00" // "/**/" -> zero or more whole directories
+	tokenTrailDoubleStar = "\x00B\x00" // "/**"  -> this dir or anything under it
+	tokenLeadDoubleStar  = "\x00C\x00" // "**/"  -> this level or any number of parent dirs
+	tokenBareDoubleStar  = "\x00D\x00" // any other "**"
+)
+
+// globToRegex translates a gitignore glob into a regular expression body
+// (without the surrounding ^$ anchors). It understands "**" (any number of
+// path segments, including zero), "*" (anything but "/"), "?" (one rune
+// but "/"), and passes bracket character classes through largely
+// unchanged.
+func globToRegex(glob string) string {
+	glob = strings.ReplaceAll(glob, "/**/", tokenMidDoubleStar)
+	if strings.HasPrefix(glob, "**/") {
+		glob = tokenLeadDoubleStar + glob[len("**/"):]
+	}
+	if strings.HasSuffix(glob, "/**") {
+		glob = glob[:len(glob)-len("/**")] + tokenTrailDoubleStar
+	}
+	glob = strings.ReplaceAll(glob, "**", tokenBareDoubleStar)
+
+	var out strings.Builder
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\x00' {
+			j := i + 2 // tokens are always "\x00<letter>\x00"
+			switch string(runes[i : j+1]) {
+			case tokenMidDoubleStar:
+				out.WriteString("/(?:.*/)?")
+			case tokenLeadDoubleStar:
+				out.WriteString("(?:.*/)?")
+			case tokenTrailDoubleStar:
+				out.WriteString("(?:/.*)?")
+			case tokenBareDoubleStar:
+				out.WriteString(".*")
+			}
+			i = j
+			continue
+		}
+		switch r := runes[i]; r {
+		case '*':
+			out.WriteString("[^/]*")
+		case '?':
+			out.WriteString("[^/]")
+		case '.', '+', '(', ')', '|', '^', '$', '{', '}':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case '[':
+			j := i + 1
+			for j < len(runes) && runes[j] != ']' {
+				j++
+			}
+			if j < len(runes) {
+				out.WriteString(string(runes[i : j+1]))
+				i = j
+			} else {
+				out.WriteString("\\[")
+			}
+		default:
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,128 @@
+package ignore_test
+
+import (
+	"testing"
+
+	"github.com/nealhardesty/code2pdf/pkg/ignore"
+)
+
+// TestMatchNegationWinsLast verifies that when multiple patterns in a
+// single pattern file match the same path, the last one wins - including a
+// later "!" negation overriding an earlier ignore, and a later ignore
+// re-ignoring something an earlier negation had spared.
+func TestMatchNegationWinsLast(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		path    string
+		ignored bool
+	}{
+		{
+			name:    "negation overrides earlier ignore",
+			lines:   []string{"*.log", "!important.log"},
+			path:    "important.log",
+			ignored: false,
+		},
+		{
+			name:    "later ignore re-ignores after earlier negation",
+			lines:   []string{"*.log", "!important.log", "important.log"},
+			path:    "important.log",
+			ignored: true,
+		},
+		{
+			name:    "unrelated file still ignored by the first pattern",
+			lines:   []string{"*.log", "!important.log"},
+			path:    "other.log",
+			ignored: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := ignore.New()
+			m.LoadDir(".", tc.lines, ".gitignore")
+
+			ignored, _, _ := m.Match(tc.path, false)
+			if ignored != tc.ignored {
+				t.Errorf("Match(%q) = %v, want %v", tc.path, ignored, tc.ignored)
+			}
+		})
+	}
+}
+
+// TestMatchDirOnly verifies that a trailing-slash pattern only matches
+// directories, not a file of the same name.
+func TestMatchDirOnly(t *testing.T) {
+	m := ignore.New()
+	m.LoadDir(".", []string{"build/"}, ".gitignore")
+
+	if ignored, _, _ := m.Match("build", true); !ignored {
+		t.Errorf("Match(%q, isDir=true) = %v, want true", "build", ignored)
+	}
+	if ignored, _, _ := m.Match("build", false); ignored {
+		t.Errorf("Match(%q, isDir=false) = %v, want false", "build", ignored)
+	}
+}
+
+// TestMatchDoubleStar exercises "**" in its three positions: a leading
+// "**/" (this level or any number of parent dirs), a trailing "/**" (this
+// dir or anything under it), and a mid-pattern "/**/" (zero or more whole
+// directories in between).
+func TestMatchDoubleStar(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		ignored bool
+	}{
+		{"leading matches nested", "**/foo.go", "a/b/foo.go", true},
+		{"leading matches top-level", "**/foo.go", "foo.go", true},
+		{"trailing matches the dir itself", "vendor/**", "vendor", true},
+		{"trailing matches file under dir", "vendor/**", "vendor/pkg/mod.go", true},
+		{"mid double-star matches zero dirs", "a/**/b", "a/b", true},
+		{"mid double-star matches nested dirs", "a/**/b", "a/x/y/b", true},
+		{"mid double-star doesn't match unrelated path", "a/**/b", "a/x/y/c", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := ignore.New()
+			m.LoadDir(".", []string{tc.pattern}, ".gitignore")
+
+			ignored, _, _ := m.Match(tc.path, false)
+			if ignored != tc.ignored {
+				t.Errorf("Match(%q) with pattern %q = %v, want %v", tc.path, tc.pattern, ignored, tc.ignored)
+			}
+		})
+	}
+}
+
+// TestMatchNestedDirScoping verifies that patterns loaded via LoadDir for a
+// subdirectory only apply within that subtree, while patterns loaded at the
+// root still cascade down into it - matching git's own per-directory
+// .gitignore scoping.
+func TestMatchNestedDirScoping(t *testing.T) {
+	m := ignore.New()
+	m.LoadDir(".", []string{"*.log"}, ".gitignore")
+	m.LoadDir("sub", []string{"*.tmp"}, ".gitignore")
+
+	tests := []struct {
+		path    string
+		ignored bool
+	}{
+		{"root.log", true},           // root pattern applies at the root
+		{"sub/nested.log", true},     // root pattern cascades into subdirectories
+		{"sub/scratch.tmp", true},    // sub's own pattern applies within its subtree
+		{"scratch.tmp", false},       // sub's pattern must not leak out to the root
+		{"other/scratch.tmp", false}, // nor into an unrelated sibling directory
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			ignored, _, _ := m.Match(tc.path, false)
+			if ignored != tc.ignored {
+				t.Errorf("Match(%q) = %v, want %v", tc.path, ignored, tc.ignored)
+			}
+		})
+	}
+}
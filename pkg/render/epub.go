@@ -0,0 +1,186 @@
+package render
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/nealhardesty/code2pdf/pkg/collect"
+	"github.com/nealhardesty/code2pdf/pkg/highlight"
+)
+
+// EPUB renders a file list as an EPUB3 package: one XHTML document per
+// file plus a nav.xhtml table of contents, zipped per the OCF container
+// spec (an uncompressed "mimetype" entry first, everything else under
+// EPUB/).
+type EPUB struct{}
+
+// Render generates an EPUB3 document from files using config.
+func (EPUB) Render(files []collect.FileEntry, config Config) error {
+	baseDir := CurrentDirectoryBase()
+
+	theme, ok := highlight.GetTheme(config.Theme)
+	if !ok {
+		theme, _ = highlight.GetTheme(highlight.DefaultTheme)
+	}
+
+	out, err := os.Create(config.OutputFile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+
+	if err := writeStoredEntry(zw, "mimetype", []byte("application/epub+zip")); err != nil {
+		return err
+	}
+	if err := writeZipString(zw, "META-INF/container.xml", containerXML); err != nil {
+		return err
+	}
+	if err := writeZipString(zw, "EPUB/nav.xhtml", navXHTML(baseDir, files)); err != nil {
+		return err
+	}
+	if err := writeZipString(zw, "EPUB/content.opf", contentOPF(baseDir, files)); err != nil {
+		return err
+	}
+
+	for i, file := range files {
+		humanReadableSize := collect.FormatFileSize(file.Size)
+		fmt.Printf("Importing %s (%s, Last Modified: %s)\n", file.Path, humanReadableSize, file.ModTime)
+
+		body, err := renderEPUBChapter(baseDir, file, config, theme)
+		if err != nil {
+			return err
+		}
+		if err := writeZipString(zw, fmt.Sprintf("EPUB/%s", chapterFilename(i)), body); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeStoredEntry adds a zip entry with no compression, as the EPUB spec
+// requires for the first "mimetype" entry.
+func writeStoredEntry(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// writeZipString adds a (deflated) zip entry containing contents.
+func writeZipString(zw *zip.Writer, name, contents string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(contents))
+	return err
+}
+
+// chapterFilename returns the XHTML filename for the i'th file.
+func chapterFilename(i int) string {
+	return fmt.Sprintf("chapter-%04d.xhtml", i)
+}
+
+// renderEPUBChapter renders one file as a standalone XHTML document,
+// reusing the same per-line tokenization as HTML.Render's code body.
+func renderEPUBChapter(baseDir string, file collect.FileEntry, config Config, theme highlight.Theme) (string, error) {
+	var body strings.Builder
+	fmt.Fprintf(&body, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&body, "<html xmlns=\"http://www.w3.org/1999/xhtml\">\n<head><meta charset=\"utf-8\"/><title>%s</title></head>\n<body>\n",
+		html.EscapeString(file.Path))
+	fmt.Fprintf(&body, "<h2>%s/%s [%s] (%s, Last Modified: %s)</h2>\n<pre>",
+		html.EscapeString(baseDir), html.EscapeString(file.Path), html.EscapeString(file.Language),
+		collect.FormatFileSize(file.Size), html.EscapeString(file.ModTime))
+
+	handle, err := os.Open(file.Path)
+	if err != nil {
+		return "", err
+	}
+	defer handle.Close()
+
+	scanner := bufio.NewScanner(handle)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		line := strings.ReplaceAll(scanner.Text(), "\t", "    ")
+		lineNum++
+
+		if config.LineNumbers {
+			fmt.Fprintf(&body, "<span class=\"line-number\">%4d | </span>", lineNum)
+		}
+
+		if config.NoHighlight {
+			fmt.Fprint(&body, html.EscapeString(line))
+		} else {
+			for _, tok := range highlight.Tokenize(file.Language, line) {
+				color := theme[tok.Class]
+				fmt.Fprintf(&body, "<span style=\"color:#%02x%02x%02x\">%s</span>", color.R, color.G, color.B, html.EscapeString(tok.Text))
+			}
+		}
+		fmt.Fprint(&body, "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(&body, "</pre>\n</body>\n</html>\n")
+	return body.String(), nil
+}
+
+// containerXML is the fixed OCF container descriptor pointing at the
+// package document; it never varies by content.
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="EPUB/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+// navXHTML builds the EPUB3 nav document, used both as the reader's table
+// of contents and as the spec-required "nav" item in the manifest.
+func navXHTML(baseDir string, files []collect.FileEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&b, "<html xmlns=\"http://www.w3.org/1999/xhtml\" xmlns:epub=\"http://www.idpf.org/2007/ops\">\n")
+	fmt.Fprintf(&b, "<head><meta charset=\"utf-8\"/><title>%s</title></head>\n<body>\n", html.EscapeString(baseDir))
+	fmt.Fprintf(&b, "<nav epub:type=\"toc\"><h1>%s</h1>\n<ol>\n", html.EscapeString(baseDir))
+	for i, file := range files {
+		fmt.Fprintf(&b, "<li><a href=\"%s\">%s/%s</a></li>\n", chapterFilename(i), html.EscapeString(baseDir), html.EscapeString(file.Path))
+	}
+	fmt.Fprintf(&b, "</ol></nav>\n</body>\n</html>\n")
+	return b.String()
+}
+
+// contentOPF builds the EPUB3 package document: metadata, the manifest of
+// every item in the book, and the linear reading order (spine).
+func contentOPF(baseDir string, files []collect.FileEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&b, "<package xmlns=\"http://www.idpf.org/2007/opf\" version=\"3.0\" unique-identifier=\"book-id\">\n")
+	fmt.Fprintf(&b, "<metadata xmlns:dc=\"http://purl.org/dc/elements/1.1/\">\n")
+	fmt.Fprintf(&b, "<dc:identifier id=\"book-id\">code2epub-%s</dc:identifier>\n", html.EscapeString(baseDir))
+	fmt.Fprintf(&b, "<dc:title>%s</dc:title>\n<dc:language>en</dc:language>\n</metadata>\n", html.EscapeString(baseDir))
+
+	fmt.Fprintf(&b, "<manifest>\n<item id=\"nav\" href=\"nav.xhtml\" media-type=\"application/xhtml+xml\" properties=\"nav\"/>\n")
+	for i := range files {
+		fmt.Fprintf(&b, "<item id=\"chapter-%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i, chapterFilename(i))
+	}
+	fmt.Fprintf(&b, "</manifest>\n")
+
+	fmt.Fprintf(&b, "<spine>\n<itemref idref=\"nav\"/>\n")
+	for i := range files {
+		fmt.Fprintf(&b, "<itemref idref=\"chapter-%d\"/>\n", i)
+	}
+	fmt.Fprintf(&b, "</spine>\n</package>\n")
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/nealhardesty/code2pdf/pkg/collect"
+	"github.com/nealhardesty/code2pdf/pkg/highlight"
+)
+
+// HTML renders a file list as a single self-contained HTML document: a
+// table of contents linking to each file's section, with the same
+// highlight.Theme used to colorize tokens via inline <span style> instead
+// of gofpdf's SetTextColor.
+type HTML struct{}
+
+// Render generates an HTML document from files using config. config.FontSize
+// and config.FontName are applied as CSS; config.Landscape and
+// config.LineNumbers map onto their natural HTML/CSS equivalents.
+func (HTML) Render(files []collect.FileEntry, config Config) error {
+	baseDir := CurrentDirectoryBase()
+
+	theme, ok := highlight.GetTheme(config.Theme)
+	if !ok {
+		theme, _ = highlight.GetTheme(highlight.DefaultTheme)
+	}
+
+	out, err := os.Create(config.OutputFile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	w := bufio.NewWriter(out)
+
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(w, "<title>%s</title>\n", html.EscapeString(baseDir))
+	fmt.Fprintf(w, "<style>\n")
+	fmt.Fprintf(w, "body { font-family: sans-serif; margin: 2em; }\n")
+	fmt.Fprintf(w, "pre.code { font-family: %s, monospace; font-size: %gpt; white-space: pre-wrap; word-break: break-all; }\n",
+		cssFontStack(config.FontName), config.FontSize)
+	fmt.Fprintf(w, ".line-number { color: #888; user-select: none; }\n")
+	fmt.Fprintf(w, "</style>\n</head>\n<body>\n")
+
+	fmt.Fprintf(w, "<h1>%s</h1>\n", html.EscapeString(baseDir))
+	fmt.Fprintf(w, "<h2>Table of Contents</h2>\n<ol>\n")
+	for i, file := range files {
+		anchor := fileAnchor(i)
+		fmt.Fprintf(w, "<li><a href=\"#%s\">%s/%s</a> [%s] (%s, Last Modified: %s)</li>\n",
+			anchor, html.EscapeString(baseDir), html.EscapeString(file.Path), html.EscapeString(file.Language),
+			collect.FormatFileSize(file.Size), html.EscapeString(file.ModTime))
+	}
+	fmt.Fprintf(w, "</ol>\n")
+
+	for i, file := range files {
+		humanReadableSize := collect.FormatFileSize(file.Size)
+		fmt.Printf("Importing %s (%s, Last Modified: %s)\n", file.Path, humanReadableSize, file.ModTime)
+
+		anchor := fileAnchor(i)
+		fmt.Fprintf(w, "<h2 id=\"%s\">%s/%s [%s] (%s, Last Modified: %s)</h2>\n",
+			anchor, html.EscapeString(baseDir), html.EscapeString(file.Path), html.EscapeString(file.Language),
+			humanReadableSize, html.EscapeString(file.ModTime))
+		fmt.Fprintf(w, "<pre class=\"code\">")
+
+		if err := writeHTMLFileBody(w, file, config, theme); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "</pre>\n")
+	}
+
+	fmt.Fprintf(w, "</body>\n</html>\n")
+	return w.Flush()
+}
+
+// writeHTMLFileBody streams file.Path line by line into w, one <span> per
+// highlighted token, mirroring the per-line structure of PDF.Render's code
+// body loop.
+func writeHTMLFileBody(w *bufio.Writer, file collect.FileEntry, config Config, theme highlight.Theme) error {
+	handle, err := os.Open(file.Path)
+	if err != nil {
+		return err
+	}
+	defer handle.Close()
+
+	scanner := bufio.NewScanner(handle)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		line := strings.ReplaceAll(scanner.Text(), "\t", "    ")
+		lineNum++
+
+		if config.LineNumbers {
+			fmt.Fprintf(w, "<span class=\"line-number\">%4d | </span>", lineNum)
+		}
+
+		if config.NoHighlight {
+			fmt.Fprint(w, html.EscapeString(line))
+		} else {
+			for _, tok := range highlight.Tokenize(file.Language, line) {
+				color := theme[tok.Class]
+				fmt.Fprintf(w, "<span style=\"color:#%02x%02x%02x\">%s</span>", color.R, color.G, color.B, html.EscapeString(tok.Text))
+			}
+		}
+		fmt.Fprint(w, "\n")
+	}
+	return scanner.Err()
+}
+
+// fileAnchor returns the HTML id/href fragment for the i'th file's section.
+func fileAnchor(i int) string {
+	return fmt.Sprintf("file-%d", i)
+}
+
+// cssFontStack maps a gofpdf core font name onto a reasonable CSS
+// monospace fallback stack, since "Courier"/"Helvetica"/"Times" aren't
+// guaranteed to resolve to a fixed-width face in a browser.
+func cssFontStack(fontName string) string {
+	switch fontName {
+	case "Helvetica":
+		return "'Helvetica Neue', Helvetica, Arial"
+	case "Times":
+		return "'Times New Roman', Times, serif"
+	default:
+		return "'Courier New', Courier"
+	}
+}
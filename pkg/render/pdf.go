@@ -0,0 +1,251 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/nealhardesty/code2pdf/pkg/collect"
+	"github.com/nealhardesty/code2pdf/pkg/highlight"
+)
+
+// PDF renders a file list as a single PDF document: a title page, a
+// clickable table of contents, and each file's content with syntax
+// highlighting, line numbers, and bookmarks.
+type PDF struct{}
+
+// Render generates a PDF document from files using config. It creates a
+// title page, table of contents, and includes each file with proper
+// formatting and page breaks.
+func (PDF) Render(files []collect.FileEntry, config Config) error {
+	baseDir := CurrentDirectoryBase()
+
+	// Set orientation based on config
+	orientation := "P" // Portrait by default
+	if config.Landscape {
+		orientation = "L" // Landscape
+	}
+
+	currentSection := "???"
+
+	theme, ok := highlight.GetTheme(config.Theme)
+	if !ok {
+		theme, _ = highlight.GetTheme(highlight.DefaultTheme)
+	}
+
+	pdf := gofpdf.New(orientation, "mm", "A4", "")
+	// Without this, gofpdf orders fonts/resources by Go's randomized map
+	// iteration, so byte-identical inputs can still produce a different
+	// (though semantically identical) PDF from one run to the next.
+	pdf.SetCatalogSort(true)
+	if !config.CreationDate.IsZero() {
+		pdf.SetCreationDate(config.CreationDate)
+		pdf.SetModificationDate(config.CreationDate)
+	}
+
+	fallbackFonts, err := setupCodeFont(pdf, config)
+	if err != nil {
+		return err
+	}
+
+	// Reserve enough bottom margin that gofpdf's automatic page breaks
+	// (triggered from inside Cell/MultiCell, not a manual threshold check)
+	// leave room for the footer.
+	bottomMargin := 27.0
+	if config.Landscape {
+		bottomMargin = 40.0
+	}
+	pdf.SetAutoPageBreak(true, bottomMargin)
+
+	// activeFile and continuedPage are read by headerFunc, which fires on
+	// every page - including ones gofpdf adds automatically mid-file, where
+	// the code-body loop below has no hook of its own to re-print a header.
+	var activeFile *collect.FileEntry
+	continuedPage := 1
+	skipNextHeader := true // the title page's own AddPage shouldn't print one
+
+	pdf.SetHeaderFunc(func() {
+		if skipNextHeader {
+			skipNextHeader = false
+			return
+		}
+		if activeFile == nil {
+			return
+		}
+		continuedPage++
+		currentSection = fmt.Sprintf("%s/%s page %d", baseDir, activeFile.Path, continuedPage)
+		pdf.SetFont(config.FontName, "B", config.FontSize+2)
+		pdf.Cell(0, 10, fmt.Sprintf("%s/%s [%s] (continued)", baseDir, activeFile.Path, activeFile.Language))
+		pdf.Ln(10)
+		pdf.SetFont(codeFontFamily, "", config.FontSize)
+	})
+
+	// Add page numbering in the footer
+	pdf.SetFooterFunc(func() {
+		// Set font for page numbers
+		pdf.SetFont("Arial", "I", 8)
+
+		// Go to 1.5 cm from bottom of the page
+		pdf.SetY(-15)
+
+		// Print page number right-aligned
+		pdf.CellFormat(0, 10, fmt.Sprintf("%s   -   [%d]", currentSection, pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.SetFont(config.FontName, "", config.FontSize)
+
+	// Allocate one internal link per file up front so the table of
+	// contents can point at them before the pages they target exist.
+	fileLinks := make([]int, len(files))
+	for i := range files {
+		fileLinks[i] = pdf.AddLink()
+	}
+
+	// Add a title page
+	pdf.AddPage()
+	pdf.SetFont(config.FontName, "B", 24)
+	pdf.Cell(0, 10, baseDir)
+	pdf.Ln(20)
+
+	currentSection = "Table of Contents"
+	// Add table of contents
+	pdf.SetFont(config.FontName, "B", 12)
+	pdf.Cell(0, 10, "Table of Contents:")
+	pdf.Ln(10)
+
+	pdf.SetFont(config.FontName, "", 12)
+
+	for i, file := range files {
+		humanReadableSize := collect.FormatFileSize(file.Size)
+		entry := fmt.Sprintf("%d. %s/%s [%s] (%s, Last Modified: %s)", i+1, baseDir, file.Path, file.Language, humanReadableSize, file.ModTime)
+		pdf.CellFormat(0, 5, entry, "", 0, "", false, fileLinks[i], "")
+		pdf.Ln(5)
+	}
+
+	// Add each file, grouped by directory so every file under the same
+	// directory nests under one top-level bookmark. gofpdf's outline
+	// nesting (putbookmarks) is purely sequential - a level-1 Bookmark call
+	// always attaches to whichever level-0 call happened most recently, by
+	// text - so if files were paged in plain sorted order, a directory with
+	// both loose files and a subdirectory (e.g. "mdir/a.go", then
+	// "mdir/sub/x.go", then "mdir/z.go") would reopen "mdir" as a second,
+	// separate top-level node once "mdir/sub" appeared in between. Pages
+	// and the table of contents still follow files' original order -
+	// renderOrder only changes the order pages are added in.
+	lastBookmarkDir := ""
+	for _, i := range groupFilesByDir(files) {
+		file := &files[i]
+		humanReadableSize := collect.FormatFileSize(file.Size)
+		fmt.Printf("Importing %s (%s, Last Modified: %s)\n", file.Path, humanReadableSize, file.ModTime)
+
+		activeFile = file
+		continuedPage = 1
+		skipNextHeader = true // we print this file's first-page header ourselves, below
+		pdf.AddPage()
+		pdf.SetLink(fileLinks[i], 0, -1)
+
+		dir := filepath.Dir(file.Path)
+		if dir != lastBookmarkDir {
+			pdf.Bookmark(dir, 0, 0)
+			lastBookmarkDir = dir
+		}
+		pdf.Bookmark(filepath.Base(file.Path), 1, 0)
+
+		// Add file header
+		pdf.SetFont(config.FontName, "B", config.FontSize+2)
+		pdf.Cell(0, 10, fmt.Sprintf("%s/%s [%s] (%s, Last Modified: %s)", baseDir, file.Path, file.Language, humanReadableSize, file.ModTime))
+		pdf.Ln(10)
+
+		currentSection = fmt.Sprintf("%s/%s page %d", baseDir, file.Path, continuedPage)
+
+		// Add file content, using the embedded (or user-supplied) UTF-8
+		// font so non-ASCII source renders correctly instead of "?". The
+		// file is streamed line-by-line rather than read into memory up
+		// front, so the whole tree's contents never sit in RAM at once.
+		pdf.SetFont(codeFontFamily, "", config.FontSize)
+		maxWidth := contentWidth(pdf, config)
+
+		handle, err := os.Open(file.Path)
+		if err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(handle)
+		scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+		lineNum := 0
+		for scanner.Scan() {
+			line := scanner.Text()
+			lineNum++
+
+			// Handle tabs (replace with spaces)
+			line = strings.ReplaceAll(line, "\t", "    ")
+
+			for rowIdx, row := range wrapRows(pdf, config, line, maxWidth) {
+				if config.LineNumbers {
+					// writeCodeText may have left a fallback font active
+					// from the previous row, so reset explicitly.
+					pdf.SetFont(codeFontFamily, "", config.FontSize)
+					if rowIdx == 0 {
+						pdf.Cell(20, 5, fmt.Sprintf("%4d | ", lineNum))
+					} else {
+						pdf.Cell(20, 5, "     ") // wrapped continuation, no line number
+					}
+				}
+
+				// Add the actual code, colorizing it token-by-token unless
+				// highlighting has been disabled. MultiCell can't mix
+				// colors within one call, so wrapRows (which uses the same
+				// GetStringWidth-based measurement MultiCell does
+				// internally) pre-wraps each line and we Cell out the
+				// colored runs per wrapped row. gofpdf's auto page break
+				// (set up above) handles pagination transparently here;
+				// headerFunc re-prints the file header on any new page.
+				if config.NoHighlight {
+					writeCodeText(pdf, config, fallbackFonts, row)
+				} else {
+					for _, tok := range highlight.Tokenize(file.Language, row) {
+						color := theme[tok.Class]
+						pdf.SetTextColor(color.R, color.G, color.B)
+						writeCodeText(pdf, config, fallbackFonts, tok.Text)
+					}
+					pdf.SetTextColor(0, 0, 0)
+				}
+				pdf.Ln(5)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			handle.Close()
+			return err
+		}
+		handle.Close()
+	}
+
+	return pdf.OutputFileAndClose(config.OutputFile)
+}
+
+// groupFilesByDir returns indices into files ordered so that every file
+// sharing a filepath.Dir value is contiguous, preserving each directory's
+// first-appearance order and each file's relative order within its
+// directory. This is the order files' pages (and thus their Bookmark
+// calls) should be emitted in, so a directory interrupted by a
+// subdirectory's entries in files' original order doesn't get split into
+// two top-level outline nodes.
+func groupFilesByDir(files []collect.FileEntry) []int {
+	groups := make(map[string][]int)
+	var dirOrder []string
+	for i, file := range files {
+		dir := filepath.Dir(file.Path)
+		if _, seen := groups[dir]; !seen {
+			dirOrder = append(dirOrder, dir)
+		}
+		groups[dir] = append(groups[dir], i)
+	}
+
+	order := make([]int, 0, len(files))
+	for _, dir := range dirOrder {
+		order = append(order, groups[dir]...)
+	}
+	return order
+}
@@ -0,0 +1,111 @@
+package render
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"unicode"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// defaultMonoTTF is the embedded fallback UTF-8 font used for the code body
+// when the user doesn't supply --ttf-font. Shipping it means non-ASCII
+// source (accented identifiers, CJK comments, box-drawing output) renders
+// correctly out of the box instead of falling back to gofpdf's single-byte
+// core fonts, which can only produce "?" for such characters.
+//
+//go:embed fonts/DejaVuSansMono.ttf
+var defaultMonoTTF []byte
+
+// codeFontFamily is the UTF-8 font family registered for the code body.
+// It's kept distinct from config.FontName, which still selects a core font
+// (Courier/Helvetica/Times) for titles and headers.
+const codeFontFamily = "Code2PDFMono"
+
+// fallbackSlot is a single --font-fallback entry: a TTF registered under
+// its own family, paired with the unicode ranges it should be used for.
+type fallbackSlot struct {
+	family string
+	ranges []*unicode.RangeTable
+}
+
+// cjkRanges covers the scripts most likely to appear in source comments and
+// strings but missing from a typical Latin monospace font.
+var cjkRanges = []*unicode.RangeTable{unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul}
+
+// emojiRanges is not part of the standard unicode package, so it's defined
+// here covering the common emoji blocks.
+var emojiRanges = []*unicode.RangeTable{
+	{R32: []unicode.Range32{{Lo: 0x2600, Hi: 0x27BF, Stride: 1}, {Lo: 0x1F300, Hi: 0x1FAFF, Stride: 1}}},
+}
+
+// setupCodeFont registers the UTF-8 TrueType font(s) used for the code
+// body: the embedded default (or --ttf-font override), plus one fallbackSlot
+// per --font-fallback path. Fallback paths are interpreted positionally:
+// the first covers CJK scripts, the second covers emoji/symbols.
+func setupCodeFont(pdf *gofpdf.Fpdf, config Config) ([]fallbackSlot, error) {
+	primary := defaultMonoTTF
+	if config.TTFFont != "" {
+		data, err := os.ReadFile(config.TTFFont)
+		if err != nil {
+			return nil, fmt.Errorf("reading --ttf-font: %w", err)
+		}
+		primary = data
+	}
+	pdf.AddUTF8FontFromBytes(codeFontFamily, "", primary)
+	pdf.AddUTF8FontFromBytes(codeFontFamily, "B", primary)
+
+	rangesBySlot := [][]*unicode.RangeTable{cjkRanges, emojiRanges}
+	var slots []fallbackSlot
+	for i, path := range config.FontFallback {
+		if i >= len(rangesBySlot) {
+			break
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading --font-fallback %q: %w", path, err)
+		}
+		family := fmt.Sprintf("%sFallback%d", codeFontFamily, i)
+		pdf.AddUTF8FontFromBytes(family, "", data)
+		slots = append(slots, fallbackSlot{family: family, ranges: rangesBySlot[i]})
+	}
+	return slots, nil
+}
+
+// fontForRune returns the fallback family that should render r, or "" if
+// the primary code font should be used.
+func fontForRune(r rune, slots []fallbackSlot) string {
+	for _, slot := range slots {
+		for _, table := range slot.ranges {
+			if unicode.Is(table, r) {
+				return slot.family
+			}
+		}
+	}
+	return ""
+}
+
+// writeCodeText renders text on the current line using the primary code
+// font, switching to a registered fallback font for any run of runes that
+// falls in its unicode range (e.g. CJK or emoji outside the primary font),
+// then switching back. The text color set by the caller (e.g. for syntax
+// highlighting) is preserved across font switches.
+func writeCodeText(pdf *gofpdf.Fpdf, config Config, slots []fallbackSlot, text string) {
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		family := fontForRune(runes[i], slots)
+		j := i + 1
+		for j < len(runes) && fontForRune(runes[j], slots) == family {
+			j++
+		}
+		run := string(runes[i:j])
+		if family == "" {
+			pdf.SetFont(codeFontFamily, "", config.FontSize)
+		} else {
+			pdf.SetFont(family, "", config.FontSize)
+		}
+		pdf.Cell(pdf.GetStringWidth(run), 5, run)
+		i = j
+	}
+}
@@ -0,0 +1,122 @@
+package render_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nealhardesty/code2pdf/pkg/collect"
+	"github.com/nealhardesty/code2pdf/pkg/compare"
+	"github.com/nealhardesty/code2pdf/pkg/highlight"
+	"github.com/nealhardesty/code2pdf/pkg/render"
+)
+
+// update regenerates testdata/golden/sample.pdf from the current renderer
+// output instead of checking it: `go test ./pkg/render/... -run TestPDFGolden -update`.
+var update = flag.Bool("update", false, "update golden files instead of comparing against them")
+
+// fixedModTime is stamped onto every fixture file before rendering, so the
+// "Last Modified" strings in the PDF don't depend on the checkout's actual
+// file timestamps.
+var fixedModTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// TestPDFGolden renders testdata/fixtures/sample and byte-compares the
+// result (after normalizing the /CreationDate field) against
+// testdata/golden/sample.pdf. Run with -update after an intentional
+// rendering change, or to create the golden file the first time.
+func TestPDFGolden(t *testing.T) {
+	repoRoot, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixtureDir := filepath.Join(repoRoot, "testdata", "fixtures", "sample")
+	goldenPath := filepath.Join(repoRoot, "testdata", "golden", "sample.pdf")
+
+	stampFixtureModTimes(t, fixtureDir)
+
+	restore := chdir(t, fixtureDir)
+	defer restore()
+
+	files, _, err := collect.Files(".")
+	if err != nil {
+		t.Fatalf("collect.Files: %v", err)
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "sample.pdf")
+	config := render.Config{
+		OutputFile:   outputFile,
+		FontSize:     7,
+		FontName:     "Courier",
+		Theme:        highlight.DefaultTheme,
+		WrapMode:     "wrap",
+		CreationDate: fixedModTime,
+	}
+	if err := (render.PDF{}).Render(files, config); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	got, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *update {
+		if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if os.IsNotExist(err) {
+		t.Fatalf("golden file %s doesn't exist yet; run with -update to create it", goldenPath)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !compare.EqualPDF(got, want) {
+		t.Fatalf("rendered PDF doesn't match %s; run with -update if this change is intentional", goldenPath)
+	}
+}
+
+// stampFixtureModTimes sets every regular file under dir to fixedModTime,
+// so collect.Files reports a deterministic "Last Modified" regardless of
+// how the checkout was created.
+func stampFixtureModTimes(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if err := os.Chtimes(path, fixedModTime, fixedModTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// chdir switches the working directory to dir and returns a func that
+// restores the original one, since CurrentDirectoryBase (and the relative
+// walk in collect.Files) both key off the process's cwd.
+func chdir(t *testing.T, dir string) func() {
+	t.Helper()
+	original, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		if err := os.Chdir(original); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// WrapModes are the valid values of the --wrap-mode flag, exported so
+// callers can validate user input before it reaches Config.WrapMode.
+var WrapModes = map[string]bool{"truncate": true, "wrap": true, "overflow": true}
+
+// DefaultWrapMode is used when an unrecognized --wrap-mode value is
+// supplied.
+const DefaultWrapMode = "wrap"
+
+// contentWidth returns the usable page width available for code text,
+// accounting for the page margins and, when enabled, the line-number
+// gutter.
+func contentWidth(pdf *gofpdf.Fpdf, config Config) float64 {
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	width := pageWidth - left - right
+	if config.LineNumbers {
+		width -= 20 // matches the line-number cell width used below
+	}
+	return width
+}
+
+// wrapRows splits line into one or more rows according to config.WrapMode:
+//   - "truncate": a single row, clipped to maxWidth
+//   - "overflow": a single row, rendered at full length (the old behavior)
+//   - "wrap" (default): as many rows as needed, each within maxWidth,
+//     breaking mid-token if a single token is wider than the page
+func wrapRows(pdf *gofpdf.Fpdf, config Config, line string, maxWidth float64) []string {
+	switch config.WrapMode {
+	case "overflow":
+		return []string{line}
+	case "truncate":
+		return []string{truncateToWidth(pdf, line, maxWidth)}
+	default:
+		return wrapToWidth(pdf, line, maxWidth)
+	}
+}
+
+// truncateToWidth returns the longest prefix of line whose rendered width
+// (in the current font) doesn't exceed maxWidth.
+func truncateToWidth(pdf *gofpdf.Fpdf, line string, maxWidth float64) string {
+	if maxWidth <= 0 || pdf.GetStringWidth(line) <= maxWidth {
+		return line
+	}
+	runes := []rune(line)
+	for i := len(runes); i > 0; i-- {
+		candidate := string(runes[:i])
+		if pdf.GetStringWidth(candidate) <= maxWidth {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// wrapToWidth greedily packs line into rows no wider than maxWidth,
+// measuring with GetStringWidth so it accounts for the active font. It
+// breaks mid-token when necessary (e.g. a long minified-JS line or a long
+// string literal with no spaces), rather than only at word boundaries.
+func wrapToWidth(pdf *gofpdf.Fpdf, line string, maxWidth float64) []string {
+	if maxWidth <= 0 || pdf.GetStringWidth(line) <= maxWidth {
+		return []string{line}
+	}
+
+	var rows []string
+	var row strings.Builder
+	for _, r := range line {
+		candidate := row.String() + string(r)
+		if row.Len() > 0 && pdf.GetStringWidth(candidate) > maxWidth {
+			rows = append(rows, row.String())
+			row.Reset()
+		}
+		row.WriteRune(r)
+	}
+	if row.Len() > 0 {
+		rows = append(rows, row.String())
+	}
+	return rows
+}
@@ -0,0 +1,76 @@
+// Package render turns a collected file list into a finished document. It
+// defines the Renderer interface implemented by each output backend (PDF,
+// HTML, EPUB) so the code2pdf-family commands can share one
+// pkg/collect.Files call and differ only in which Renderer they hand the
+// result to.
+package render
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/nealhardesty/code2pdf/pkg/collect"
+)
+
+// Config holds the settings common to every renderer. Not every field is
+// meaningful to every backend (e.g. Landscape only affects PDF), the same
+// way code2pdf's original Config carried flags for features later renderers
+// may ignore.
+type Config struct {
+	OutputFile   string
+	FontSize     float64
+	FontName     string
+	LineNumbers  bool
+	Landscape    bool
+	Theme        string
+	NoHighlight  bool
+	TTFFont      string
+	FontFallback []string
+	WrapMode     string
+
+	// CreationDate, when non-zero, overrides the document's embedded
+	// creation timestamp - normally the current time, which would
+	// otherwise make output non-reproducible from one run to the next.
+	// Commands populate this from --creation-date or SOURCE_DATE_EPOCH
+	// (see ReproducibleCreationDate).
+	CreationDate time.Time
+}
+
+// ReproducibleCreationDate resolves the document creation timestamp a
+// command should use: explicit takes priority, then SOURCE_DATE_EPOCH (the
+// reproducible-builds convention: https://reproducible-builds.org/specs/source-date-epoch/),
+// then the zero Time, which tells each Renderer to fall back to the
+// current time.
+func ReproducibleCreationDate(explicit string) (time.Time, error) {
+	if explicit != "" {
+		return time.Parse("2006-01-02T15:04:05Z", explicit)
+	}
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parsing SOURCE_DATE_EPOCH: %w", err)
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	return time.Time{}, nil
+}
+
+// Renderer turns a collected file list into a finished document at
+// config.OutputFile.
+type Renderer interface {
+	Render(files []collect.FileEntry, config Config) error
+}
+
+// CurrentDirectoryBase returns the base name of the current working
+// directory. It's used for the document title and headers. Returns "???"
+// if unable to determine.
+func CurrentDirectoryBase() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "???"
+	}
+	return filepath.Base(dir)
+}
@@ -0,0 +1,8 @@
+package main
+
+import "fmt"
+
+// main prints a greeting.
+func main() {
+	fmt.Println("hello, sample fixture")
+}